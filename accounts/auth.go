@@ -19,7 +19,7 @@ func CreateUser(c *gin.Context) {
 	var authInput AuthInput
 
 	if err := c.ShouldBindJSON(&authInput); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		core.RespondBindError(c, err)
 		return
 	}
 
@@ -53,7 +53,7 @@ func Login(c *gin.Context) {
 	var authInput AuthInput
 
 	if err := c.ShouldBindJSON(&authInput); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		core.RespondBindError(c, err)
 		return
 	}
 
@@ -5,6 +5,12 @@ import "triplanner/core"
 type User struct {
 	core.BaseModel
 	Username string `json:"username" gorm:"unique"`
-	Password string `json:"password"`
+	Password string `json:"-"`
 	Email    *string
+
+	DisplayName     *string `json:"display_name"`
+	DefaultCurrency *string `json:"default_currency"`
+	HomeCity        *string `json:"home_city"`
+	Locale          *string `json:"locale"`
+	Timezone        *string `json:"timezone"`
 }
@@ -0,0 +1,19 @@
+package accounts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUserJSONOmitsPassword(t *testing.T) {
+	user := User{Username: "ada", Password: "$2a$10$hash"}
+
+	body, err := json.Marshal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "password") || strings.Contains(string(body), user.Password) {
+		t.Errorf("user JSON exposes the password hash: %s", body)
+	}
+}
@@ -0,0 +1,121 @@
+package accounts
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"triplanner/core"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+// NormalizeCurrency validates an ISO 4217 code and returns it upper-cased.
+func NormalizeCurrency(code string) (string, error) {
+	unit, err := currency.ParseISO(strings.TrimSpace(code))
+	if err != nil {
+		return "", fmt.Errorf("invalid currency %q: must be an ISO 4217 code", code)
+	}
+	return unit.String(), nil
+}
+
+// NormalizeTimezone validates an IANA time zone name and returns it trimmed.
+// "Local" is rejected: LoadLocation accepts it, but it means whatever zone
+// the server happens to run in.
+func NormalizeTimezone(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "Local" {
+		return "", fmt.Errorf("invalid timezone %q: must be an IANA time zone name", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("invalid timezone %q: must be an IANA time zone name", name)
+	}
+	return name, nil
+}
+
+// normalizeProfileInput trims every field, validates currency (ISO 4217),
+// locale (BCP 47) and timezone (IANA), and rewrites them in canonical form.
+func normalizeProfileInput(input *UpdateProfileInput) error {
+	for _, field := range []*string{input.DisplayName, input.DefaultCurrency, input.HomeCity, input.Locale, input.Timezone} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+
+	if input.DefaultCurrency != nil && *input.DefaultCurrency != "" {
+		code, err := NormalizeCurrency(*input.DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*input.DefaultCurrency = code
+	}
+	if input.Locale != nil && *input.Locale != "" {
+		tag, err := language.Parse(*input.Locale)
+		if err != nil {
+			return fmt.Errorf("invalid locale %q: must be a BCP 47 language tag", *input.Locale)
+		}
+		*input.Locale = tag.String()
+	}
+	if input.Timezone != nil && *input.Timezone != "" {
+		name, err := NormalizeTimezone(*input.Timezone)
+		if err != nil {
+			return err
+		}
+		*input.Timezone = name
+	}
+	return nil
+}
+
+// applyProfileField sets dst from a present input field, treating an empty
+// string as "clear".
+func applyProfileField(dst **string, value *string) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		*dst = nil
+		return
+	}
+	v := *value
+	*dst = &v
+}
+
+func UpdateUserProfile(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(User)
+
+	var input UpdateProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		core.RespondBindError(c, err)
+		return
+	}
+	if err := normalizeProfileInput(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user User
+	if err := core.DB.First(&user, "id = ?", currentUser.ID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	applyProfileField(&user.DisplayName, input.DisplayName)
+	applyProfileField(&user.DefaultCurrency, input.DefaultCurrency)
+	applyProfileField(&user.HomeCity, input.HomeCity)
+	applyProfileField(&user.Locale, input.Locale)
+	applyProfileField(&user.Timezone, input.Timezone)
+
+	err := core.DB.Model(&user).
+		Select("DisplayName", "DefaultCurrency", "HomeCity", "Locale", "Timezone").
+		Updates(&user).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
@@ -0,0 +1,120 @@
+package accounts
+
+import "testing"
+
+func str(s string) *string {
+	return &s
+}
+
+func TestNormalizeProfileInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   UpdateProfileInput
+		want    UpdateProfileInput
+		wantErr bool
+	}{
+		{
+			name:  "nothing set",
+			input: UpdateProfileInput{},
+			want:  UpdateProfileInput{},
+		},
+		{
+			name:  "trims free text",
+			input: UpdateProfileInput{DisplayName: str("  Ada  "), HomeCity: str(" London ")},
+			want:  UpdateProfileInput{DisplayName: str("Ada"), HomeCity: str("London")},
+		},
+		{
+			name:  "upper-cases currency",
+			input: UpdateProfileInput{DefaultCurrency: str(" eur ")},
+			want:  UpdateProfileInput{DefaultCurrency: str("EUR")},
+		},
+		{
+			name:  "canonicalises locale",
+			input: UpdateProfileInput{Locale: str("en_us")},
+			want:  UpdateProfileInput{Locale: str("en-US")},
+		},
+		{
+			name:  "trims timezone",
+			input: UpdateProfileInput{Timezone: str(" Europe/Paris ")},
+			want:  UpdateProfileInput{Timezone: str("Europe/Paris")},
+		},
+		{
+			name:  "empty strings are kept so they clear the field",
+			input: UpdateProfileInput{DefaultCurrency: str(""), Locale: str(" "), Timezone: str("")},
+			want:  UpdateProfileInput{DefaultCurrency: str(""), Locale: str(""), Timezone: str("")},
+		},
+		{
+			name:    "unknown currency",
+			input:   UpdateProfileInput{DefaultCurrency: str("EURO")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid locale",
+			input:   UpdateProfileInput{Locale: str("not a locale")},
+			wantErr: true,
+		},
+		{
+			name:    "unknown timezone",
+			input:   UpdateProfileInput{Timezone: str("Mars/Olympus_Mons")},
+			wantErr: true,
+		},
+		{
+			name:    "server local timezone",
+			input:   UpdateProfileInput{Timezone: str("Local")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			err := normalizeProfileInput(&input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fields := []struct {
+				name      string
+				got, want *string
+			}{
+				{"display_name", input.DisplayName, tt.want.DisplayName},
+				{"default_currency", input.DefaultCurrency, tt.want.DefaultCurrency},
+				{"home_city", input.HomeCity, tt.want.HomeCity},
+				{"locale", input.Locale, tt.want.Locale},
+				{"timezone", input.Timezone, tt.want.Timezone},
+			}
+			for _, f := range fields {
+				if (f.got == nil) != (f.want == nil) || (f.got != nil && *f.got != *f.want) {
+					t.Errorf("%s = %v, want %v", f.name, deref(f.got), deref(f.want))
+				}
+			}
+		})
+	}
+}
+
+func TestApplyProfileFieldClearsOnEmpty(t *testing.T) {
+	current := str("EUR")
+
+	applyProfileField(&current, nil)
+	if current == nil || *current != "EUR" {
+		t.Fatalf("absent field changed the value to %v", deref(current))
+	}
+
+	applyProfileField(&current, str(""))
+	if current != nil {
+		t.Errorf("empty string left %q, want nil", *current)
+	}
+}
+
+func deref(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
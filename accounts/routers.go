@@ -15,6 +15,7 @@ func RouterGroupUserAuth(router *gin.RouterGroup) {
 
 func RouterGroupUserProfile(router *gin.RouterGroup) {
 	router.GET("/profile", GetUserProfile)
+	router.PUT("/profile", UpdateUserProfile)
 }
 
 func RouterGroupGoogleOAuth(router *gin.RouterGroup) {
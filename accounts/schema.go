@@ -4,3 +4,13 @@ type AuthInput struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+// UpdateProfileInput only changes the fields that are present; an empty
+// string clears a field.
+type UpdateProfileInput struct {
+	DisplayName     *string `json:"display_name"`
+	DefaultCurrency *string `json:"default_currency"`
+	HomeCity        *string `json:"home_city"`
+	Locale          *string `json:"locale"`
+	Timezone        *string `json:"timezone"`
+}
@@ -17,6 +17,8 @@ func init() {
 
 func main() {
 	router := gin.Default()
+	router.Use(core.CORSMiddleware())
+	router.Use(core.BodyLimitMiddleware())
 	router.LoadHTMLGlob("templates/*")
 
 	router.GET("/ping", func(c *gin.Context) {
@@ -31,10 +33,12 @@ func main() {
 
 	v1.Use(accounts.CheckAuth)
 	places.RouterGroupPlacesAPI(v1.Group("/places"))
+	places.RouterGroupFavorites(v1.Group("/favorites"))
 
-	trips.RouterGroupCreateTrip(v1.Group("/trips"))
+	trips.RouterGroupTrips(v1.Group("/trips"))
 
 	accounts.RouterGroupUserProfile(v1.Group("/user"))
+	trips.RouterGroupUserTags(v1.Group("/user"))
 
 	router.Run() // listen and serve on 0.0.0.0:8080
 }
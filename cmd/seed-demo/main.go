@@ -0,0 +1,108 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+	"triplanner/accounts"
+	"triplanner/core"
+	"triplanner/trips"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	demoUsername = "demo"
+	demoPassword = "demo-password"
+)
+
+func init() {
+	core.LoadEnvs()
+	core.ConnectDB()
+}
+
+func main() {
+	if os.Getenv("ALLOW_DEMO_SEED") != "true" {
+		log.Fatal("Refusing to seed demo data: set ALLOW_DEMO_SEED=true to enable")
+	}
+	if os.Getenv("GIN_MODE") == "release" {
+		log.Fatal("Refusing to seed demo data in release mode")
+	}
+
+	user := seedDemoUser()
+	for _, trip := range demoTrips(user) {
+		result := core.DB.Where(trips.TripPlan{UserID: user.ID, PlaceName: trip.PlaceName}).FirstOrCreate(&trip)
+		if result.Error != nil {
+			log.Fatalf("Failed to seed trip %s: %v", trip.PlaceName, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Created trip %s (%s)", trip.PlaceName, trip.ID)
+		} else {
+			log.Printf("Trip %s already exists (%s)", trip.PlaceName, trip.ID)
+		}
+	}
+
+	log.Printf("Demo data ready, log in as %q / %q", demoUsername, demoPassword)
+}
+
+func seedDemoUser() accounts.User {
+	var user accounts.User
+	core.DB.Where("username = ?", demoUsername).Find(&user)
+	if user.Username != "" {
+		log.Printf("Demo user already exists (%s)", user.ID)
+		return user
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(demoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Fatalf("Failed to hash demo password: %v", err)
+	}
+	email := "demo@example.com"
+	user = accounts.User{
+		Username: demoUsername,
+		Password: string(passwordHash),
+		Email:    &email,
+	}
+	if err := core.DB.Create(&user).Error; err != nil {
+		log.Fatalf("Failed to create demo user: %v", err)
+	}
+	log.Printf("Created demo user (%s)", user.ID)
+	return user
+}
+
+func demoTrips(user accounts.User) []trips.TripPlan {
+	date := func(value string) *time.Time {
+		t, _ := time.Parse(time.DateOnly, value)
+		return &t
+	}
+	days := func(n int16) *int16 { return &n }
+	text := func(s string) *string { return &s }
+
+	return []trips.TripPlan{
+		{
+			PlaceName:  "Paris, France",
+			StartDate:  date("2025-05-10"),
+			EndDate:    date("2025-05-16"),
+			MinDays:    days(5),
+			MaxDays:    days(7),
+			TravelMode: text("flight"),
+			Notes:      text("Museums in the morning, cafés in the afternoon."),
+			Hotels:     pq.StringArray{"Hôtel du Louvre"},
+			Tags:       trips.NormalizeTags(pq.StringArray{"Europe", "culture", "food"}),
+			UserID:     user.ID,
+		},
+		{
+			PlaceName:  "Kyoto, Japan",
+			StartDate:  date("2025-10-02"),
+			EndDate:    date("2025-10-09"),
+			MinDays:    days(6),
+			MaxDays:    days(10),
+			TravelMode: text("train"),
+			Notes:      text("Temples, gardens and a day trip to Nara."),
+			Hotels:     pq.StringArray{"Hotel Kanra Kyoto"},
+			Tags:       trips.NormalizeTags(pq.StringArray{"Asia", "culture", "autumn"}),
+			UserID:     user.ID,
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowedHeaders = "Authorization, Content-Type, Accept, Origin, X-Requested-With"
+	corsExposedHeaders = "Authorization"
+	corsMaxAge         = "43200"
+
+	defaultMaxBodyBytes int64 = 1 << 20
+)
+
+// corsOrigins holds the parsed ALLOWED_ORIGINS list. Entries are either exact
+// origins ("https://app.example.com"), subdomain wildcards
+// ("https://*.example.com") or "*" to allow any origin without credentials.
+type corsOrigins struct {
+	exact     map[string]bool
+	wildcards []string
+	any       bool
+}
+
+func parseCORSOrigins(value string) corsOrigins {
+	origins := corsOrigins{exact: map[string]bool{}}
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		switch {
+		case origin == "":
+		case origin == "*":
+			origins.any = true
+		case strings.Contains(origin, "://*."):
+			origins.wildcards = append(origins.wildcards, origin)
+		default:
+			origins.exact[origin] = true
+		}
+	}
+	return origins
+}
+
+// listed reports whether the origin was explicitly configured, either
+// exactly or through a subdomain wildcard.
+func (o corsOrigins) listed(origin string) bool {
+	if o.exact[origin] {
+		return true
+	}
+	for _, pattern := range o.wildcards {
+		scheme, domain, _ := strings.Cut(pattern, "*")
+		if !strings.HasPrefix(origin, scheme) || !strings.HasSuffix(origin, domain) {
+			continue
+		}
+		subdomain := strings.TrimSuffix(strings.TrimPrefix(origin, scheme), domain)
+		if subdomain != "" && !strings.ContainsAny(subdomain, "/:") {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSMiddleware applies the ALLOWED_ORIGINS policy and answers preflight
+// requests. Credentials are only allowed for explicitly listed origins.
+func CORSMiddleware() gin.HandlerFunc {
+	origins := parseCORSOrigins(os.Getenv("ALLOWED_ORIGINS"))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		c.Writer.Header().Add("Vary", "Origin")
+
+		switch {
+		case origins.listed(origin):
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Credentials", "true")
+		case origins.any:
+			c.Header("Access-Control-Allow-Origin", "*")
+		default:
+			if preflight {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+				return
+			}
+			c.Next()
+			return
+		}
+		c.Header("Access-Control-Expose-Headers", corsExposedHeaders)
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", corsAllowedMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowedHeaders)
+			c.Header("Access-Control-Max-Age", corsMaxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+func envBytes(key string, fallback int64) int64 {
+	if v, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+// BodyLimitMiddleware caps request bodies at MAX_BODY_BYTES. Bodies that
+// declare a larger Content-Length are rejected with 413 up front; others
+// are wrapped in a MaxBytesReader so reading past the limit fails, which
+// handlers turn into a 413 through RespondBindError.
+func BodyLimitMiddleware() gin.HandlerFunc {
+	limit := envBytes("MAX_BODY_BYTES", defaultMaxBodyBytes)
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "Request body exceeds " + strconv.FormatInt(limit, 10) + " bytes",
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// RespondBindError answers a failed ShouldBindJSON: 413 when the body was
+// cut off by BodyLimitMiddleware, 400 for anything else.
+func RespondBindError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Request body exceeds " + strconv.FormatInt(maxBytesErr.Limit, 10) + " bytes",
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
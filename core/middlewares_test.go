@@ -0,0 +1,180 @@
+package core
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCORSRouter(t *testing.T, allowedOrigins string) *gin.Engine {
+	t.Helper()
+	t.Setenv("ALLOWED_ORIGINS", allowedOrigins)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(CORSMiddleware())
+	router.POST("/api/v1/trips/create", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+	return router
+}
+
+func preflight(router *gin.Engine, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/trips/create", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func simpleRequest(router *gin.Engine, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/trips/create", nil)
+	req.Header.Set("Origin", origin)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSPreflightAllowedOrigin(t *testing.T) {
+	router := newCORSRouter(t, "https://app.example.com")
+
+	w := preflight(router, "https://app.example.com")
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	headers := map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.example.com",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Allow-Methods":     corsAllowedMethods,
+		"Access-Control-Allow-Headers":     corsAllowedHeaders,
+		"Access-Control-Expose-Headers":    "Authorization",
+	}
+	for name, want := range headers {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCORSDisallowedOrigin(t *testing.T) {
+	router := newCORSRouter(t, "https://app.example.com")
+
+	w := preflight(router, "https://evil.com")
+	if w.Code != http.StatusForbidden {
+		t.Errorf("preflight status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("preflight Access-Control-Allow-Origin = %q, want none", got)
+	}
+
+	w = simpleRequest(router, "https://evil.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("simple request Access-Control-Allow-Origin = %q, want none", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("simple request Access-Control-Allow-Credentials = %q, want none", got)
+	}
+}
+
+func TestCORSWildcardSubdomains(t *testing.T) {
+	origins := parseCORSOrigins("https://*.example.com")
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.com", true},
+		{"https://a.b.example.com", true},
+		{"https://example.com", false},
+		{"https://a.example.com:8080", false},
+		{"http://app.example.com", false},
+		{"https://app.example.com.evil.com", false},
+		{"https://evilexample.com", false},
+	}
+	for _, tt := range tests {
+		if got := origins.listed(tt.origin); got != tt.want {
+			t.Errorf("listed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestCORSAnyOriginNeverAllowsCredentials(t *testing.T) {
+	router := newCORSRouter(t, "*, https://app.example.com")
+
+	for _, w := range []*httptest.ResponseRecorder{
+		preflight(router, "https://other.com"),
+		simpleRequest(router, "https://other.com"),
+	} {
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+			t.Errorf("Access-Control-Allow-Credentials = %q alongside *, want none", got)
+		}
+	}
+
+	w := simpleRequest(router, "https://app.example.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("listed origin Access-Control-Allow-Origin = %q, want echoed origin", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("listed origin Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
+
+func newBodyLimitRouter(t *testing.T, maxBytes string) *gin.Engine {
+	t.Helper()
+	t.Setenv("MAX_BODY_BYTES", maxBytes)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(BodyLimitMiddleware())
+	router.POST("/echo", func(c *gin.Context) {
+		var input map[string]string
+		if err := c.ShouldBindJSON(&input); err != nil {
+			RespondBindError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, input)
+	})
+	return router
+}
+
+func TestBodyLimit(t *testing.T) {
+	oversized := `{"notes":"` + strings.Repeat("x", 100) + `"}`
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		want          int
+	}{
+		{"small body", `{"notes":"ok"}`, -2, http.StatusOK},
+		{"declared length over limit", oversized, -2, http.StatusRequestEntityTooLarge},
+		{"chunked body over limit", oversized, -1, http.StatusRequestEntityTooLarge},
+		{"body longer than declared length", oversized, 10, http.StatusRequestEntityTooLarge},
+		{"malformed json", `{"notes":`, -2, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newBodyLimitRouter(t, "64")
+			req := httptest.NewRequest(http.MethodPost, "/echo", io.NopCloser(strings.NewReader(tt.body)))
+			req.ContentLength = int64(len(tt.body))
+			if tt.contentLength != -2 {
+				req.ContentLength = tt.contentLength
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// Providers that share the outbound client. Each one reads its settings
+// from <PROVIDER>_RATE_LIMIT, <PROVIDER>_BURST, <PROVIDER>_TIMEOUT and
+// <PROVIDER>_MAX_RETRIES, e.g. MAPBOX_RATE_LIMIT=10.
+const (
+	PROVIDER_MAPBOX      = "MAPBOX"
+	PROVIDER_GOOGLE_MAPS = "GOOGLE_MAPS"
+)
+
+const (
+	defaultOutboundRateLimit  = 10.0
+	defaultOutboundBurst      = 5
+	defaultOutboundTimeout    = 10 * time.Second
+	defaultOutboundMaxRetries = 3
+	outboundBaseBackoff       = 500 * time.Millisecond
+	outboundMaxBackoff        = 10 * time.Second
+)
+
+// QuotaExceededError is returned when a provider keeps answering 429, asks
+// us to back off for longer than we are willing to wait, or when the rate
+// limiter can't admit the request before the caller's deadline. Handlers
+// should turn it into a 503 with Retry-After.
+type QuotaExceededError struct {
+	Provider   string
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s quota exhausted, retry after %s", strings.ToLower(e.Provider), e.RetryAfter)
+}
+
+type OutboundConfig struct {
+	RateLimit  float64
+	Burst      int
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+func LoadOutboundConfig(provider string) OutboundConfig {
+	config := OutboundConfig{
+		RateLimit:  defaultOutboundRateLimit,
+		Burst:      defaultOutboundBurst,
+		Timeout:    defaultOutboundTimeout,
+		MaxRetries: defaultOutboundMaxRetries,
+	}
+	if v, err := strconv.ParseFloat(os.Getenv(provider+"_RATE_LIMIT"), 64); err == nil && v > 0 {
+		config.RateLimit = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(provider + "_BURST")); err == nil && v > 0 {
+		config.Burst = v
+	}
+	if v, err := time.ParseDuration(os.Getenv(provider + "_TIMEOUT")); err == nil && v > 0 {
+		config.Timeout = v
+	}
+	if v, err := strconv.Atoi(os.Getenv(provider + "_MAX_RETRIES")); err == nil && v >= 0 {
+		config.MaxRetries = v
+	}
+	return config
+}
+
+var (
+	outboundClients   = map[string]*http.Client{}
+	outboundClientsMu sync.Mutex
+)
+
+// OutboundClient returns the shared HTTP client for a provider, creating it
+// on first use so the environment has already been loaded by then.
+func OutboundClient(provider string) *http.Client {
+	outboundClientsMu.Lock()
+	defer outboundClientsMu.Unlock()
+
+	if client, ok := outboundClients[provider]; ok {
+		return client
+	}
+	client := NewOutboundClient(provider, LoadOutboundConfig(provider))
+	outboundClients[provider] = client
+	return client
+}
+
+// NewOutboundClient builds a client whose timeout applies to each attempt
+// rather than to the whole call, so retries and backoff don't eat into it.
+func NewOutboundClient(provider string, config OutboundConfig) *http.Client {
+	return &http.Client{
+		Transport: &outboundTransport{
+			provider:   provider,
+			limiter:    rate.NewLimiter(rate.Limit(config.RateLimit), config.Burst),
+			timeout:    config.Timeout,
+			maxRetries: config.MaxRetries,
+			next:       http.DefaultTransport,
+		},
+	}
+}
+
+type outboundTransport struct {
+	provider   string
+	limiter    *rate.Limiter
+	timeout    time.Duration
+	maxRetries int
+	next       http.RoundTripper
+}
+
+func (t *outboundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	// Requests with a body can only be retried if it can be re-read.
+	retryable := req.Body == nil || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if err := t.waitForToken(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, t.timeout)
+		attemptReq := req.Clone(attemptCtx)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := retryAfter(resp, attempt)
+		quotaExhausted := resp.StatusCode == http.StatusTooManyRequests
+		// A provider asking us to back off longer than we'd ever wait, or
+		// past the caller's deadline, is treated as out of quota right away.
+		giveUp := !retryable || attempt >= t.maxRetries || exceedsDeadline(ctx, wait) ||
+			(quotaExhausted && wait > outboundMaxBackoff)
+		if giveUp {
+			if quotaExhausted {
+				resp.Body.Close()
+				cancel()
+				return nil, &QuotaExceededError{Provider: t.provider, RetryAfter: wait}
+			}
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		resp.Body.Close()
+		cancel()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// waitForToken blocks until the rate limiter allows another request. If the
+// wait would outlast the caller's deadline it fails fast with a
+// QuotaExceededError instead of a context error.
+func (t *outboundTransport) waitForToken(ctx context.Context) error {
+	reservation := t.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if exceedsDeadline(ctx, delay) {
+		reservation.Cancel()
+		return &QuotaExceededError{Provider: t.provider, RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+func exceedsDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Now().Add(wait).After(deadline)
+}
+
+// cancelOnClose releases an attempt's timeout context once the caller is
+// done reading the response body.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryAfter honours the provider's Retry-After header and otherwise backs
+// off exponentially from outboundBaseBackoff.
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	backoff := time.Duration(float64(outboundBaseBackoff) * math.Pow(2, float64(attempt)))
+	if backoff > outboundMaxBackoff {
+		backoff = outboundMaxBackoff
+	}
+	return backoff
+}
+
+// IsOutboundError reports whether err came from the outbound client itself
+// (quota exhaustion or a transport failure) rather than from the provider's
+// response.
+func IsOutboundError(err error) bool {
+	var quotaErr *QuotaExceededError
+	var urlErr *url.Error
+	return errors.As(err, &quotaErr) || errors.As(err, &urlErr)
+}
+
+// RespondOutboundError writes the response for a failed provider call:
+// 503 with Retry-After when the quota is exhausted, 502 otherwise. The
+// underlying error can contain the request URL and its API key, so it is
+// only logged.
+func RespondOutboundError(c *gin.Context, provider string, err error) {
+	log.Printf("%s request failed: %v", strings.ToLower(provider), err)
+
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		seconds := int(math.Ceil(quotaErr.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": quotaErr.Error()})
+		return
+	}
+	c.JSON(http.StatusBadGateway, gin.H{"error": strings.ToLower(provider) + " request failed"})
+}
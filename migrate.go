@@ -3,6 +3,7 @@ package main
 import (
 	"triplanner/accounts"
 	"triplanner/core"
+	"triplanner/places"
 	"triplanner/trips"
 )
 
@@ -12,5 +13,5 @@ func init() {
 }
 
 func main() {
-	core.DB.AutoMigrate(&accounts.User{}, &trips.TripPlan{})
+	core.DB.AutoMigrate(&accounts.User{}, &trips.TripPlan{}, &places.Favorite{})
 }
@@ -1,7 +1,6 @@
 package places
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"os"
@@ -20,16 +19,23 @@ func SearchAutocomplete(c *gin.Context) {
 	pretty.Println("Text: ", SearchText)
 
 	SessionToken := uuid.Must(uuid.NewV4()).String()
-	response_data := make_http_request(MapboxApi{"GET", string(Autosuggest), SearchText, MapboxAPIKey, SessionToken, ""})
+	response_data, err := make_http_request(c.Request.Context(), MapboxApi{"GET", string(Autosuggest), SearchText, MapboxAPIKey, SessionToken, ""})
+	if err != nil {
+		core.RespondOutboundError(c, core.PROVIDER_MAPBOX, err)
+		return
+	}
 	var res MapboxAPIResponse
-	json.Unmarshal(response_data, &res)
+	if err := json.Unmarshal(response_data, &res); err != nil {
+		core.RespondOutboundError(c, core.PROVIDER_MAPBOX, err)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"data": res})
 }
 
 func PlaceDetails(c *gin.Context) {
 
 	apiKey := os.Getenv("GOOGLE_API_KEY")
-	client, _ := maps.NewClient(maps.WithAPIKey(apiKey))
+	client, _ := maps.NewClient(maps.WithAPIKey(apiKey), maps.WithHTTPClient(core.OutboundClient(core.PROVIDER_GOOGLE_MAPS)))
 	data := c.Request.URL.Query()
 	pretty.Println("Json: ", data)
 
@@ -37,8 +43,10 @@ func PlaceDetails(c *gin.Context) {
 		PlaceID: data.Get("place_id"),
 	}
 	pretty.Println("Request: ", request)
-	resp, err := client.PlaceDetails(context.Background(), request)
-	if err != nil {
+	resp, err := client.PlaceDetails(c.Request.Context(), request)
+	if core.IsOutboundError(err) {
+		core.RespondOutboundError(c, core.PROVIDER_GOOGLE_MAPS, err)
+	} else if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		pretty.Println("Error: ", err.Error())
 	} else {
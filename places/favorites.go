@@ -0,0 +1,100 @@
+package places
+
+import (
+	"net/http"
+	"strings"
+	"triplanner/accounts"
+	"triplanner/core"
+	"triplanner/trips"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func CreateFavorite(c *gin.Context) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	var input CreateFavoriteRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		core.RespondBindError(c, err)
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" && (input.PlaceID == nil || *input.PlaceID == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "either name or place_id is required"})
+		return
+	}
+
+	if input.TripPlanID != nil {
+		var count int64
+		core.DB.Model(&trips.TripPlan{}).Where("id = ? AND user_id = ?", *input.TripPlanID, user.ID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "trip plan not found"})
+			return
+		}
+	}
+
+	favorite := Favorite{
+		UserID:     user.ID,
+		EntityType: input.EntityType,
+		PlaceID:    input.PlaceID,
+		Name:       input.Name,
+		Address:    input.Address,
+		Notes:      input.Notes,
+		TripPlanID: input.TripPlanID,
+	}
+	if err := core.DB.Create(&favorite).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": favorite})
+}
+
+func ListFavorites(c *gin.Context) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	query := core.DB.Where("user_id = ?", user.ID)
+	if tripPlanID := c.Query("trip_plan_id"); tripPlanID != "" {
+		id, err := uuid.Parse(tripPlanID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid trip_plan_id"})
+			return
+		}
+		query = query.Where("trip_plan_id = ?", id)
+	}
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	var favorites []Favorite
+	if err := query.Order("created_at DESC").Find(&favorites).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": favorites})
+}
+
+func DeleteFavorite(c *gin.Context) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid favorite id"})
+		return
+	}
+
+	result := core.DB.Where("id = ? AND user_id = ?", id, user.ID).Delete(&Favorite{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "favorite not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
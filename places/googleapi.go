@@ -1,9 +1,9 @@
 package places
 
 import (
-	"context"
 	"net/http"
 	"os"
+	"triplanner/core"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kr/pretty"
@@ -12,7 +12,7 @@ import (
 
 func search(c *gin.Context) {
 	apiKey := os.Getenv("GOOGLE_API_KEY")
-	client, _ := maps.NewClient(maps.WithAPIKey(apiKey))
+	client, _ := maps.NewClient(maps.WithAPIKey(apiKey), maps.WithHTTPClient(core.OutboundClient(core.PROVIDER_GOOGLE_MAPS)))
 	sessiontoken := maps.NewPlaceAutocompleteSessionToken()
 	data := c.Request.URL.Query()
 	pretty.Println("Json: ", data)
@@ -26,8 +26,10 @@ func search(c *gin.Context) {
 		SessionToken: sessiontoken,
 	}
 	pretty.Println("Request: ", request)
-	resp, err := client.PlaceAutocomplete(context.Background(), request)
-	if err != nil {
+	resp, err := client.PlaceAutocomplete(c.Request.Context(), request)
+	if core.IsOutboundError(err) {
+		core.RespondOutboundError(c, core.PROVIDER_GOOGLE_MAPS, err)
+	} else if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		pretty.Println("Error: ", err.Error())
 	} else {
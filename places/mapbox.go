@@ -1,10 +1,11 @@
 package places
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"triplanner/core"
 )
 
 const BASE_MAPBOX_API = "https://api.mapbox.com"
@@ -70,19 +71,23 @@ func GetAPIUrl(data MapboxApi) string {
 
 }
 
-func make_http_request(data MapboxApi) []byte {
-	url := GetAPIUrl(data)
-	resp, err := http.Get(url)
+func make_http_request(ctx context.Context, data MapboxApi) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, data.Method, GetAPIUrl(data), nil)
 	if err != nil {
-		// handle error
+		return nil, err
+	}
+	resp, err := core.OutboundClient(core.PROVIDER_MAPBOX).Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatalln(err)
+	// The outbound client hands back the last 5xx once it stops retrying, and
+	// 4xx responses as is; neither carries results.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("mapbox responded %s", resp.Status)
 	}
-	return body
+	return io.ReadAll(resp.Body)
 }
 
 // func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
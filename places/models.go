@@ -0,0 +1,28 @@
+package places
+
+import (
+	"triplanner/core"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteEntityType string
+
+const (
+	FavoritePlace    FavoriteEntityType = "place"
+	FavoriteActivity FavoriteEntityType = "activity"
+)
+
+// Favorite is a bookmarked place or activity idea that has not been added
+// to an itinerary yet. It references an external place ID, free text, or
+// both, and can optionally be scoped to one trip plan.
+type Favorite struct {
+	core.BaseModel
+	UserID     uuid.UUID          `json:"user_id" gorm:"index"`
+	EntityType FavoriteEntityType `json:"entity_type"`
+	PlaceID    *string            `json:"place_id"`
+	Name       string             `json:"name"`
+	Address    *string            `json:"address"`
+	Notes      *string            `json:"notes"`
+	TripPlanID *uuid.UUID         `json:"trip_plan_id" gorm:"type:uuid;index"`
+}
@@ -6,3 +6,9 @@ func RouterGroupPlacesAPI(router *gin.RouterGroup) {
 	router.GET("/autocomplete/search", SearchAutocomplete)
 	router.GET("/details", PlaceDetails)
 }
+
+func RouterGroupFavorites(router *gin.RouterGroup) {
+	router.GET("", ListFavorites)
+	router.POST("", CreateFavorite)
+	router.DELETE("/:id", DeleteFavorite)
+}
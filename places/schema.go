@@ -0,0 +1,12 @@
+package places
+
+import "github.com/google/uuid"
+
+type CreateFavoriteRequest struct {
+	EntityType FavoriteEntityType `json:"entity_type" binding:"required,oneof=place activity"`
+	PlaceID    *string            `json:"place_id"`
+	Name       string             `json:"name"`
+	Address    *string            `json:"address"`
+	Notes      *string            `json:"notes"`
+	TripPlanID *uuid.UUID         `json:"trip_plan_id"`
+}
@@ -1,21 +1,171 @@
 package trips
 
 import (
+	"log"
 	"net/http"
+	"triplanner/accounts"
+	"triplanner/core"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 func CreateTrip(c *gin.Context) {
 	var newTrip CreateTripRequest
 
-	// Call BindJSON to bind the received JSON to
-	// newAlbum.
-	if err := c.BindJSON(&newTrip); err != nil {
+	// Call ShouldBindJSON to bind the received JSON to
+	// newTrip.
+	if err := c.ShouldBindJSON(&newTrip); err != nil {
+		core.RespondBindError(c, err)
 		return
 	}
 
-	// Add the new album to the slice.
-	c.IndentedJSON(http.StatusCreated, newTrip)
+	warnings, err := ValidateTripDuration(newTrip)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	newTrip.Tags = NormalizeTags(newTrip.Tags)
+
+	user := c.MustGet("currentUser").(accounts.User)
+	defaultsApplied, err := applyUserDefaults(&newTrip, user)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	participants := NormalizeParticipants(newTrip.Participants)
+	if len(participants.Rejected) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid participants", "rejected": participants.Rejected})
+		return
+	}
+	if err := resolveRegisteredParticipants(&participants); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	trip := TripPlan{
+		PlaceName:    newTrip.PlaceName,
+		PlaceID:      newTrip.PlaceID,
+		StartDate:    newTrip.StartDate,
+		EndDate:      newTrip.EndDate,
+		MinDays:      newTrip.MinDays,
+		MaxDays:      newTrip.MaxDays,
+		TravelMode:   newTrip.TravelMode,
+		Notes:        newTrip.Notes,
+		Hotels:       newTrip.Hotels,
+		Tags:         newTrip.Tags,
+		Currency:     newTrip.Currency,
+		Timezone:     newTrip.Timezone,
+		Participants: participants.Accepted,
+		UserID:       user.ID,
+
+		DefaultActivityBuffer: newTrip.DefaultActivityBuffer,
+	}
+	if err := core.DB.Create(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// The trip is already saved, so a failure here only leaves it without a
+	// share code; failing the request would invite a duplicate create.
+	if err := AssignShareCode(core.DB, &trip); err != nil {
+		log.Printf("assign share code to trip %s: %v", trip.ID, err)
+	}
+
+	c.IndentedJSON(http.StatusCreated, gin.H{
+		"data":             trip,
+		"warnings":         warnings,
+		"defaults_applied": defaultsApplied,
+		"participants":     participants,
+	})
+
+}
+
+func ListTrips(c *gin.Context) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	query := core.DB.Where("user_id = ?", user.ID)
+	if tag := NormalizeTag(c.Query("tag")); tag != "" {
+		// Containment rather than = ANY(tags), which the GIN index can't serve.
+		query = query.Where("tags @> ?", pq.StringArray{tag})
+	}
+
+	switch c.DefaultQuery("sort", "recent") {
+	case "pinned":
+		query = query.Order("is_pinned DESC").Order("pin_order ASC NULLS LAST")
+	case "recent":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of: recent, pinned"})
+		return
+	}
+
+	var trips []TripPlan
+	if err := query.Order("created_at DESC").Find(&trips).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trips})
+}
+
+func PinTrip(c *gin.Context) {
+	setTripPin(c, true)
+}
+
+func UnpinTrip(c *gin.Context) {
+	setTripPin(c, false)
+}
+
+func setTripPin(c *gin.Context, pinned bool) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	var input PinTripRequest
+	if pinned && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			core.RespondBindError(c, err)
+			return
+		}
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid trip plan id"})
+		return
+	}
+
+	var trip TripPlan
+	if err := core.DB.Where("id = ? AND user_id = ?", id, user.ID).First(&trip).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "trip plan not found"})
+		return
+	}
+
+	trip.IsPinned = pinned
+	trip.PinOrder = nil
+	if pinned {
+		trip.PinOrder = input.Order
+	}
+	if err := core.DB.Model(&trip).Select("IsPinned", "PinOrder").Updates(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trip})
+}
+
+func GetUserTags(c *gin.Context) {
+	user := c.MustGet("currentUser").(accounts.User)
+
+	var tags []TagUsage
+	err := core.DB.Raw(`SELECT tag, COUNT(*) AS count
+		FROM trip_plans, unnest(tags) AS tag
+		WHERE user_id = ?
+		GROUP BY tag
+		ORDER BY count DESC, tag`, user.ID).Scan(&tags).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
 }
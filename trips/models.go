@@ -15,11 +15,26 @@ type TripPlan struct {
 	PlaceID    string
 	StartDate  *time.Time
 	EndDate    *time.Time
-	MinDays    *int8
+	MinDays    *int16
+	MaxDays    *int16
 	TravelMode *string
 	Notes      *string
 	Hotels     pq.StringArray `gorm:"type:text[]"`
-	Tags       pq.StringArray `gorm:"type:text[]"`
+	Tags       pq.StringArray `gorm:"type:text[];index:idx_trip_plans_tags,type:gin"`
+	IsPinned   bool           `gorm:"default:false"`
+	PinOrder   *int
+	ShareCode  *string `gorm:"uniqueIndex"`
 	UserID     uuid.UUID
 	User       accounts.User
+
+	// DefaultActivityBuffer is the gap in minutes assumed between
+	// activities, e.g. 30 for a relaxed trip or 10 for a packed one. It is a
+	// pointer so that 0 is stored as is; nil falls back to the column default.
+	DefaultActivityBuffer *int16 `gorm:"default:15"`
+
+	Currency *string
+	Timezone *string
+
+	// Participants are the lowercased emails of people invited to the trip.
+	Participants pq.StringArray `gorm:"type:text[]"`
 }
@@ -0,0 +1,74 @@
+package trips
+
+import (
+	"net/mail"
+	"strings"
+	"triplanner/accounts"
+	"triplanner/core"
+
+	"github.com/lib/pq"
+)
+
+type RejectedParticipant struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
+}
+
+// ParticipantsResult reports how a participant list was normalized and
+// which of the remaining emails belong to existing accounts.
+type ParticipantsResult struct {
+	Accepted   pq.StringArray        `json:"accepted"`
+	Rejected   []RejectedParticipant `json:"rejected"`
+	Registered []string              `json:"registered"`
+}
+
+// NormalizeParticipants lowercases and dedupes participant emails and
+// rejects anything that is not a bare, well-formed address.
+func NormalizeParticipants(emails pq.StringArray) ParticipantsResult {
+	result := ParticipantsResult{
+		Accepted:   pq.StringArray{},
+		Rejected:   []RejectedParticipant{},
+		Registered: []string{},
+	}
+	seen := map[string]bool{}
+
+	for _, raw := range emails {
+		email := strings.ToLower(strings.TrimSpace(raw))
+		if email == "" {
+			result.Rejected = append(result.Rejected, RejectedParticipant{Email: raw, Reason: "empty email"})
+			continue
+		}
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			result.Rejected = append(result.Rejected, RejectedParticipant{Email: raw, Reason: "invalid email format"})
+			continue
+		}
+		if domain := email[strings.LastIndex(email, "@")+1:]; !strings.Contains(domain, ".") {
+			result.Rejected = append(result.Rejected, RejectedParticipant{Email: raw, Reason: "email domain is missing a top-level domain"})
+			continue
+		}
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		result.Accepted = append(result.Accepted, email)
+	}
+	return result
+}
+
+// resolveRegisteredParticipants fills in which accepted emails already
+// belong to a user account.
+func resolveRegisteredParticipants(result *ParticipantsResult) error {
+	if len(result.Accepted) == 0 {
+		return nil
+	}
+	var registered []string
+	err := core.DB.Model(&accounts.User{}).
+		Where("LOWER(email) IN ?", []string(result.Accepted)).
+		Pluck("LOWER(email)", &registered).Error
+	if err != nil {
+		return err
+	}
+	result.Registered = registered
+	return nil
+}
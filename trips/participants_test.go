@@ -0,0 +1,102 @@
+package trips
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestNormalizeParticipants(t *testing.T) {
+	tests := []struct {
+		name         string
+		emails       pq.StringArray
+		wantAccepted pq.StringArray
+		wantRejected []RejectedParticipant
+	}{
+		{
+			name:         "no participants",
+			emails:       nil,
+			wantAccepted: pq.StringArray{},
+			wantRejected: []RejectedParticipant{},
+		},
+		{
+			name:         "trims and lowercases",
+			emails:       pq.StringArray{"  Ada@Example.COM "},
+			wantAccepted: pq.StringArray{"ada@example.com"},
+			wantRejected: []RejectedParticipant{},
+		},
+		{
+			name:         "dedupes after case folding and keeps first-seen order",
+			emails:       pq.StringArray{"bob@example.com", "ada@example.com", "BOB@example.com ", "ada@example.com"},
+			wantAccepted: pq.StringArray{"bob@example.com", "ada@example.com"},
+			wantRejected: []RejectedParticipant{},
+		},
+		{
+			name:         "subdomains are accepted",
+			emails:       pq.StringArray{"ada@mail.example.co.uk"},
+			wantAccepted: pq.StringArray{"ada@mail.example.co.uk"},
+			wantRejected: []RejectedParticipant{},
+		},
+		{
+			name:         "empty entries",
+			emails:       pq.StringArray{"", "   "},
+			wantAccepted: pq.StringArray{},
+			wantRejected: []RejectedParticipant{
+				{Email: "", Reason: "empty email"},
+				{Email: "   ", Reason: "empty email"},
+			},
+		},
+		{
+			name:         "display name form is not a bare address",
+			emails:       pq.StringArray{"Ada Lovelace <ada@example.com>"},
+			wantAccepted: pq.StringArray{},
+			wantRejected: []RejectedParticipant{
+				{Email: "Ada Lovelace <ada@example.com>", Reason: "invalid email format"},
+			},
+		},
+		{
+			name:         "malformed addresses",
+			emails:       pq.StringArray{"ada", "ada@", "@example.com", "ada@@example.com", "ada@example.com, bob@example.com"},
+			wantAccepted: pq.StringArray{},
+			wantRejected: []RejectedParticipant{
+				{Email: "ada", Reason: "invalid email format"},
+				{Email: "ada@", Reason: "invalid email format"},
+				{Email: "@example.com", Reason: "invalid email format"},
+				{Email: "ada@@example.com", Reason: "invalid email format"},
+				{Email: "ada@example.com, bob@example.com", Reason: "invalid email format"},
+			},
+		},
+		{
+			name:         "dotless domain",
+			emails:       pq.StringArray{"ada@localhost"},
+			wantAccepted: pq.StringArray{},
+			wantRejected: []RejectedParticipant{
+				{Email: "ada@localhost", Reason: "email domain is missing a top-level domain"},
+			},
+		},
+		{
+			name:         "mixed input keeps the raw rejected value",
+			emails:       pq.StringArray{"Ada@Example.com", " Bob@LOCALHOST ", "ada@example.com"},
+			wantAccepted: pq.StringArray{"ada@example.com"},
+			wantRejected: []RejectedParticipant{
+				{Email: " Bob@LOCALHOST ", Reason: "email domain is missing a top-level domain"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizeParticipants(tt.emails)
+			if !reflect.DeepEqual(result.Accepted, tt.wantAccepted) {
+				t.Errorf("accepted = %q, want %q", result.Accepted, tt.wantAccepted)
+			}
+			if !reflect.DeepEqual(result.Rejected, tt.wantRejected) {
+				t.Errorf("rejected = %+v, want %+v", result.Rejected, tt.wantRejected)
+			}
+			if len(result.Registered) != 0 {
+				t.Errorf("registered = %q, want none before the lookup", result.Registered)
+			}
+		})
+	}
+}
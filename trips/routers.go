@@ -2,6 +2,13 @@ package trips
 
 import "github.com/gin-gonic/gin"
 
-func RouterGroupCreateTrip(router *gin.RouterGroup) {
+func RouterGroupTrips(router *gin.RouterGroup) {
+	router.GET("", ListTrips)
 	router.POST("/create", CreateTrip)
+	router.POST("/:id/pin", PinTrip)
+	router.DELETE("/:id/pin", UnpinTrip)
+}
+
+func RouterGroupUserTags(router *gin.RouterGroup) {
+	router.GET("/tags", GetUserTags)
 }
@@ -14,10 +14,22 @@ type CreateTripRequest struct {
 	StartDate  *time.Time     `json:"start_date"`
 	EndDate    *time.Time     `json:"end_date"`
 	MinDays    *int16         `json:"min_days"`
+	MaxDays    *int16         `json:"max_days"`
 	TravelMode *string        `json:"travel_mode"`
 	Notes      *string        `json:"notes"`
 	Hotels     pq.StringArray `gorm:"type:text[]"`
 	Tags       pq.StringArray `gorm:"type:text[]"`
 	UserID     uuid.UUID
 	User       accounts.User
+
+	DefaultActivityBuffer *int16 `json:"default_activity_buffer" binding:"omitempty,min=0,max=720"`
+
+	Currency *string `json:"currency"`
+	Timezone *string `json:"timezone"`
+
+	Participants pq.StringArray `json:"participants"`
+}
+
+type PinTripRequest struct {
+	Order *int `json:"order" binding:"omitempty,min=0"`
 }
@@ -0,0 +1,108 @@
+package trips
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	shareCodeBytes      = 5 // 8 base32 characters, 40 bits of entropy
+	shareCodeMaxRetries = 5
+)
+
+var shareCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func newShareCode() (string, error) {
+	buf := make([]byte, shareCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return shareCodeEncoding.EncodeToString(buf), nil
+}
+
+// shareCodeStore is the storage the share-code helpers need. setShareCode
+// must return gorm.ErrDuplicatedKey when the unique index rejects a code.
+type shareCodeStore interface {
+	shareCodeExists(code string) (bool, error)
+	setShareCode(tripID uuid.UUID, code string) error
+}
+
+type gormShareCodeStore struct {
+	db *gorm.DB
+}
+
+func (s gormShareCodeStore) shareCodeExists(code string) (bool, error) {
+	var count int64
+	err := s.db.Model(&TripPlan{}).Where("share_code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+func (s gormShareCodeStore) setShareCode(tripID uuid.UUID, code string) error {
+	result := s.db.Model(&TripPlan{}).Where("id = ?", tripID).Update("share_code", code)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GenerateUniqueShareCode returns a random share code that no trip plan is
+// using yet. Two requests can still race for the same code, so callers
+// should persist it with AssignShareCode, which relies on the unique index.
+func GenerateUniqueShareCode(db *gorm.DB) (string, error) {
+	return generateUniqueShareCode(gormShareCodeStore{db: db})
+}
+
+// AssignShareCode gives the trip a share code, retrying with a fresh code if
+// a concurrent request claimed the same one first.
+func AssignShareCode(db *gorm.DB, trip *TripPlan) error {
+	return assignShareCode(gormShareCodeStore{db: db}, trip)
+}
+
+func generateUniqueShareCode(store shareCodeStore) (string, error) {
+	for attempt := 0; attempt < shareCodeMaxRetries; attempt++ {
+		code, err := newShareCode()
+		if err != nil {
+			return "", err
+		}
+
+		exists, err := store.shareCodeExists(code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate a unique share code after %d attempts", shareCodeMaxRetries)
+}
+
+func assignShareCode(store shareCodeStore, trip *TripPlan) error {
+	if trip.ShareCode != nil {
+		return nil
+	}
+	for attempt := 0; attempt < shareCodeMaxRetries; attempt++ {
+		code, err := generateUniqueShareCode(store)
+		if err != nil {
+			return err
+		}
+
+		err = store.setShareCode(trip.ID, code)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		trip.ShareCode = &code
+		return nil
+	}
+	return fmt.Errorf("could not assign a unique share code after %d attempts", shareCodeMaxRetries)
+}
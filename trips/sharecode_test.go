@@ -0,0 +1,153 @@
+package trips
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeShareCodeStore reports the first len(taken) looked-up codes as taken
+// and fails the first len(updateErrs) writes with the given errors.
+type fakeShareCodeStore struct {
+	taken      []bool
+	updateErrs []error
+	lookups    []string
+	writes     []string
+}
+
+func (s *fakeShareCodeStore) shareCodeExists(code string) (bool, error) {
+	s.lookups = append(s.lookups, code)
+	if i := len(s.lookups) - 1; i < len(s.taken) {
+		return s.taken[i], nil
+	}
+	return false, nil
+}
+
+func (s *fakeShareCodeStore) setShareCode(tripID uuid.UUID, code string) error {
+	s.writes = append(s.writes, code)
+	if i := len(s.writes) - 1; i < len(s.updateErrs) {
+		return s.updateErrs[i]
+	}
+	return nil
+}
+
+var shareCodePattern = regexp.MustCompile(`^[A-Z2-7]{8}$`)
+
+func TestNewShareCodeFormat(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		code, err := newShareCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !shareCodePattern.MatchString(code) {
+			t.Fatalf("share code %q is not 8 unpadded base32 characters", code)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 999 {
+		t.Errorf("got only %d distinct codes out of 1000", len(seen))
+	}
+}
+
+func TestGenerateUniqueShareCodeRetriesWhenTaken(t *testing.T) {
+	store := &fakeShareCodeStore{taken: []bool{true, true}}
+
+	code, err := generateUniqueShareCode(store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.lookups) != 3 {
+		t.Fatalf("checked %d codes, want 3", len(store.lookups))
+	}
+	if code != store.lookups[2] {
+		t.Errorf("returned %q, want the first free code %q", code, store.lookups[2])
+	}
+}
+
+func TestGenerateUniqueShareCodeGivesUp(t *testing.T) {
+	store := &fakeShareCodeStore{taken: []bool{true, true, true, true, true, true}}
+
+	if _, err := generateUniqueShareCode(store); err == nil {
+		t.Fatal("expected an error when every code is taken")
+	}
+	if len(store.lookups) != shareCodeMaxRetries {
+		t.Errorf("checked %d codes, want %d", len(store.lookups), shareCodeMaxRetries)
+	}
+}
+
+func TestAssignShareCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		updateErrs []error
+		wantErr    bool
+		wantWrites int
+	}{
+		{
+			name:       "first code is stored",
+			wantWrites: 1,
+		},
+		{
+			name:       "retries when a concurrent request took the code",
+			updateErrs: []error{gorm.ErrDuplicatedKey},
+			wantWrites: 2,
+		},
+		{
+			name:       "gives up after repeated collisions",
+			updateErrs: []error{gorm.ErrDuplicatedKey, gorm.ErrDuplicatedKey, gorm.ErrDuplicatedKey, gorm.ErrDuplicatedKey, gorm.ErrDuplicatedKey},
+			wantErr:    true,
+			wantWrites: shareCodeMaxRetries,
+		},
+		{
+			name:       "other errors are returned immediately",
+			updateErrs: []error{errors.New("connection reset")},
+			wantErr:    true,
+			wantWrites: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeShareCodeStore{updateErrs: tt.updateErrs}
+			trip := &TripPlan{}
+			trip.ID = uuid.New()
+
+			err := assignShareCode(store, trip)
+			if len(store.writes) != tt.wantWrites {
+				t.Errorf("wrote %d codes, want %d", len(store.writes), tt.wantWrites)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if trip.ShareCode != nil {
+					t.Errorf("trip share code = %q, want nil after a failed assignment", *trip.ShareCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			last := store.writes[len(store.writes)-1]
+			if trip.ShareCode == nil || *trip.ShareCode != last {
+				t.Errorf("trip share code = %v, want the stored code %q", trip.ShareCode, last)
+			}
+		})
+	}
+}
+
+func TestAssignShareCodeKeepsExistingCode(t *testing.T) {
+	store := &fakeShareCodeStore{}
+	existing := "ABCDEFGH"
+	trip := &TripPlan{ShareCode: &existing}
+
+	if err := assignShareCode(store, trip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.lookups) != 0 || len(store.writes) != 0 {
+		t.Errorf("touched the store for a trip that already has a code")
+	}
+}
@@ -0,0 +1,35 @@
+package trips
+
+import (
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// TagUsage is the number of times a user has applied a tag.
+type TagUsage struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// NormalizeTag trims and lowercases a tag so "Europe " and "europe" are
+// stored the same way.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags normalizes every tag, dropping empty entries and duplicates
+// while keeping the original order.
+func NormalizeTags(tags pq.StringArray) pq.StringArray {
+	seen := make(map[string]bool, len(tags))
+	normalized := pq.StringArray{}
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
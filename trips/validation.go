@@ -0,0 +1,109 @@
+package trips
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"triplanner/accounts"
+)
+
+// DurationWarning describes a trip whose date range falls outside its
+// MinDays/MaxDays window. It is reported to the client but does not
+// block the request.
+type DurationWarning struct {
+	Field   string `json:"field"`
+	Limit   int    `json:"limit"`
+	Actual  int    `json:"actual"`
+	Message string `json:"message"`
+}
+
+// tripDurationDays returns the number of calendar days covered by the
+// trip, counting both the start and end day.
+func tripDurationDays(start, end time.Time) int {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(endDay.Sub(startDay).Hours()/24) + 1
+}
+
+// ValidateTripDuration checks the dates and day window of a trip request.
+// An error is returned for inconsistent input (end before start, min above
+// max); a duration outside the min/max window only produces warnings.
+func ValidateTripDuration(trip CreateTripRequest) ([]DurationWarning, error) {
+	warnings := []DurationWarning{}
+
+	if trip.MinDays != nil && *trip.MinDays < 0 {
+		return nil, errors.New("min_days must not be negative")
+	}
+	if trip.MaxDays != nil && *trip.MaxDays < 0 {
+		return nil, errors.New("max_days must not be negative")
+	}
+	if trip.MinDays != nil && trip.MaxDays != nil && *trip.MinDays > *trip.MaxDays {
+		return nil, fmt.Errorf("min_days (%d) must not be greater than max_days (%d)", *trip.MinDays, *trip.MaxDays)
+	}
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return warnings, nil
+	}
+	if trip.EndDate.Before(*trip.StartDate) {
+		return nil, fmt.Errorf("end_date (%s) must not be before start_date (%s)",
+			trip.EndDate.Format(time.DateOnly), trip.StartDate.Format(time.DateOnly))
+	}
+
+	days := tripDurationDays(*trip.StartDate, *trip.EndDate)
+	if trip.MinDays != nil && days < int(*trip.MinDays) {
+		warnings = append(warnings, DurationWarning{
+			Field:   "min_days",
+			Limit:   int(*trip.MinDays),
+			Actual:  days,
+			Message: fmt.Sprintf("trip lasts %d days, which is %d fewer than min_days", days, int(*trip.MinDays)-days),
+		})
+	}
+	if trip.MaxDays != nil && days > int(*trip.MaxDays) {
+		warnings = append(warnings, DurationWarning{
+			Field:   "max_days",
+			Limit:   int(*trip.MaxDays),
+			Actual:  days,
+			Message: fmt.Sprintf("trip lasts %d days, which is %d more than max_days", days, days-int(*trip.MaxDays)),
+		})
+	}
+	return warnings, nil
+}
+
+// applyUserDefaults validates the trip's currency and timezone and fills
+// in any that were omitted from the user's profile. It returns the JSON
+// names of the fields that were inherited.
+func applyUserDefaults(trip *CreateTripRequest, user accounts.User) ([]string, error) {
+	applied := []string{}
+
+	// A blank value is treated as omitted, so it is stored as NULL rather
+	// than as an empty string when the profile has no default either.
+	for _, field := range []**string{&trip.Currency, &trip.Timezone} {
+		if *field != nil && strings.TrimSpace(**field) == "" {
+			*field = nil
+		}
+	}
+
+	if trip.Currency != nil {
+		code, err := accounts.NormalizeCurrency(*trip.Currency)
+		if err != nil {
+			return nil, err
+		}
+		trip.Currency = &code
+	} else if user.DefaultCurrency != nil {
+		trip.Currency = user.DefaultCurrency
+		applied = append(applied, "currency")
+	}
+
+	if trip.Timezone != nil {
+		name, err := accounts.NormalizeTimezone(*trip.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		trip.Timezone = &name
+	} else if user.Timezone != nil {
+		trip.Timezone = user.Timezone
+		applied = append(applied, "timezone")
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,226 @@
+package trips
+
+import (
+	"testing"
+	"time"
+	"triplanner/accounts"
+)
+
+func date(value string) *time.Time {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func days(n int16) *int16 {
+	return &n
+}
+
+func TestValidateTripDuration(t *testing.T) {
+	tests := []struct {
+		name         string
+		trip         CreateTripRequest
+		wantErr      bool
+		wantWarnings []string
+	}{
+		{
+			name: "all nil",
+			trip: CreateTripRequest{},
+		},
+		{
+			name: "nil start date",
+			trip: CreateTripRequest{EndDate: date("2025-06-05"), MinDays: days(10)},
+		},
+		{
+			name: "nil end date",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), MaxDays: days(1)},
+		},
+		{
+			name: "nil min and max",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-05")},
+		},
+		{
+			name: "start equals end is one day",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-01"), MinDays: days(1), MaxDays: days(1)},
+		},
+		{
+			name:         "one day is below min of two",
+			trip:         CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-01"), MinDays: days(2)},
+			wantWarnings: []string{"min_days"},
+		},
+		{
+			name: "duration exactly at min",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-05"), MinDays: days(5)},
+		},
+		{
+			name:         "duration one below min",
+			trip:         CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-04"), MinDays: days(5)},
+			wantWarnings: []string{"min_days"},
+		},
+		{
+			name: "duration exactly at max",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-07"), MaxDays: days(7)},
+		},
+		{
+			name:         "duration one above max",
+			trip:         CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-08"), MaxDays: days(7)},
+			wantWarnings: []string{"max_days"},
+		},
+		{
+			name: "min equals max",
+			trip: CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-03"), MinDays: days(3), MaxDays: days(3)},
+		},
+		{
+			name:    "end before start",
+			trip:    CreateTripRequest{StartDate: date("2025-06-05"), EndDate: date("2025-06-01")},
+			wantErr: true,
+		},
+		{
+			name:    "min greater than max",
+			trip:    CreateTripRequest{MinDays: days(8), MaxDays: days(7)},
+			wantErr: true,
+		},
+		{
+			name:    "negative min",
+			trip:    CreateTripRequest{MinDays: days(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "negative max",
+			trip:    CreateTripRequest{MaxDays: days(-1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateTripDuration(tt.trip)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got warnings %+v", warnings)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got %d warnings %+v, want fields %v", len(warnings), warnings, tt.wantWarnings)
+			}
+			for i, field := range tt.wantWarnings {
+				if warnings[i].Field != field {
+					t.Errorf("warning %d field = %q, want %q", i, warnings[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTripDurationReportsDiscrepancy(t *testing.T) {
+	trip := CreateTripRequest{StartDate: date("2025-06-01"), EndDate: date("2025-06-10"), MaxDays: days(7)}
+
+	warnings, err := ValidateTripDuration(trip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1", len(warnings))
+	}
+	if warnings[0].Limit != 7 || warnings[0].Actual != 10 {
+		t.Errorf("got limit %d actual %d, want limit 7 actual 10", warnings[0].Limit, warnings[0].Actual)
+	}
+}
+
+func text(s string) *string {
+	return &s
+}
+
+func TestApplyUserDefaults(t *testing.T) {
+	profile := accounts.User{DefaultCurrency: text("EUR"), Timezone: text("Europe/Paris")}
+
+	tests := []struct {
+		name         string
+		trip         CreateTripRequest
+		user         accounts.User
+		wantCurrency *string
+		wantTimezone *string
+		wantApplied  []string
+		wantErr      bool
+	}{
+		{
+			name: "nothing set anywhere",
+		},
+		{
+			name: "blank values without profile defaults stay unset",
+			trip: CreateTripRequest{Currency: text(""), Timezone: text("  ")},
+		},
+		{
+			name:         "blank values fall back to the profile",
+			trip:         CreateTripRequest{Currency: text(""), Timezone: text("")},
+			user:         profile,
+			wantCurrency: text("EUR"),
+			wantTimezone: text("Europe/Paris"),
+			wantApplied:  []string{"currency", "timezone"},
+		},
+		{
+			name:         "explicit values win over the profile",
+			trip:         CreateTripRequest{Currency: text(" usd "), Timezone: text(" Asia/Tokyo ")},
+			user:         profile,
+			wantCurrency: text("USD"),
+			wantTimezone: text("Asia/Tokyo"),
+		},
+		{
+			name:    "invalid currency",
+			trip:    CreateTripRequest{Currency: text("dollars")},
+			wantErr: true,
+		},
+		{
+			name:    "server local timezone",
+			trip:    CreateTripRequest{Timezone: text("Local")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := tt.trip
+			applied, err := applyUserDefaults(&trip, tt.user)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equalText(trip.Currency, tt.wantCurrency) {
+				t.Errorf("currency = %v, want %v", deref(trip.Currency), deref(tt.wantCurrency))
+			}
+			if !equalText(trip.Timezone, tt.wantTimezone) {
+				t.Errorf("timezone = %v, want %v", deref(trip.Timezone), deref(tt.wantTimezone))
+			}
+			if len(applied) != len(tt.wantApplied) {
+				t.Fatalf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+			for i := range applied {
+				if applied[i] != tt.wantApplied[i] {
+					t.Errorf("applied = %v, want %v", applied, tt.wantApplied)
+				}
+			}
+		})
+	}
+}
+
+func equalText(got, want *string) bool {
+	return (got == nil) == (want == nil) && (got == nil || *got == *want)
+}
+
+func deref(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}